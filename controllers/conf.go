@@ -0,0 +1,19 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/conf"
+	"github.com/gin-gonic/gin"
+)
+
+// ReloadConfig 重新加载配置文件，是 SIGHUP/命名管道之外的另一种热重载触发方式，
+// 便于跑在容器里、不方便直接发信号的部署场景
+func ReloadConfig(c *gin.Context) {
+	if err := conf.ReloadCurrent(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/conf"
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/health"
+	"github.com/gin-gonic/gin"
+)
+
+// respondRCloneError 统一处理RClone相关接口的错误响应：err 由于某个绑定处于
+// 熔断状态（health.ErrRemoteUnavailable）导致时返回503，方便调用方和"参数错误/
+// 绑定不存在"这类问题区分开；不是熔断导致的错误仍按调用方指定的 fallbackStatus
+// 返回。本仓库里实际做文件上传/下载的 handler 不在这次改动范围内，但应当复用
+// 这个函数而不是各自重复判断 errors.Is(err, health.ErrRemoteUnavailable)
+func respondRCloneError(c *gin.Context, fallbackStatus int, err error) {
+	if errors.Is(err, health.ErrRemoteUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(fallbackStatus, gin.H{"error": err.Error()})
+}
+
+// ListRCloneBinds 列出当前生效的 RClone 绑定
+func ListRCloneBinds(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"binds": conf.ListRCloneBinds()})
+}
+
+// AddRCloneBindService 新增绑定请求体，Options 为 "ro,cache=full,..." 形式的
+// 可选挂载参数，与配置文件中 Binds 的第三段语法一致
+type AddRCloneBindService struct {
+	Target  string `json:"target" binding:"required"`
+	Remote  string `json:"remote" binding:"required"`
+	Options string `json:"options"`
+}
+
+// AddRCloneBind 新增一个 target:remote 绑定并持久化到配置文件
+func AddRCloneBind(c *gin.Context) {
+	var service AddRCloneBindService
+	if err := c.ShouldBindJSON(&service); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := conf.AddRCloneBind(service.Target, service.Remote, service.Options); err != nil {
+		respondRCloneError(c, http.StatusConflict, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"binds": conf.ListRCloneBinds()})
+}
+
+// RemoveRCloneBindService 移除绑定请求体
+type RemoveRCloneBindService struct {
+	Target string `json:"target" binding:"required"`
+}
+
+// RemoveRCloneBind 卸载 target 处的绑定并持久化到配置文件
+func RemoveRCloneBind(c *gin.Context) {
+	var service RemoveRCloneBindService
+	if err := c.ShouldBindJSON(&service); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := conf.RemoveRCloneBind(service.Target); err != nil {
+		respondRCloneError(c, http.StatusNotFound, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"binds": conf.ListRCloneBinds()})
+}
+
+// ReloadRCloneBinds 重新从配置文件解析 Binds 并应用到运行中的 BindManager
+func ReloadRCloneBinds(c *gin.Context) {
+	if err := conf.ReloadRCloneBinds(); err != nil {
+		respondRCloneError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"binds": conf.ListRCloneBinds()})
+}
+
+// RCloneStatus 返回每个绑定的健康状态（healthy|degraded|down、上次探测时间、
+// RTT、最近一次错误），未开启健康检查（health<=0）的绑定 Health 字段为 null
+func RCloneStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"binds": conf.ListRCloneBinds()})
+}
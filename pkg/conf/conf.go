@@ -1,16 +1,27 @@
 package conf
 
 import (
+	"errors"
+	"fmt"
+	"github.com/cloudreve/Cloudreve/v3/pkg/conf/secret"
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/backend"
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/bind"
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/manager"
 	"github.com/cloudreve/Cloudreve/v3/pkg/util"
 	"github.com/go-ini/ini"
 	"github.com/spf13/afero"
 	"github.com/spf13/afero/rclonefs"
 	"gopkg.in/go-playground/validator.v9"
+	"os"
 	"path/filepath"
-	"runtime"
+	"reflect"
 	"strings"
+	"sync/atomic"
 )
 
+// ErrRCloneBindDisabled 表示当前系统未启用 RClone 绑定管理（未配置 Binds 或挂载后端选择失败）
+var ErrRCloneBindDisabled = errors.New("RClone绑定未启用")
+
 // database 数据库
 type database struct {
 	Type        string
@@ -90,13 +101,82 @@ type cors struct {
 }
 
 // RClone配置
-// Binds /mnt/ibm:ibm
+// Binds /mnt/ibm:ibm 或 /mnt/ibm:ibm:ro,cache=full,poll=15s,chunk=32M,max-cache=10G,upload-concurrency=4
+// Backend 挂载后端: auto(默认，按平台选择)|afero|fuse|nfs|webdav
 type rclone struct {
-	Config     string
-	Binds     []string
+	Config  string
+	Binds   []string
+	Backend string `validate:"omitempty,eq=auto|eq=afero|eq=fuse|eq=nfs|eq=webdav"`
 }
 
-var cfg *ini.File
+// cfgPtr 持有当前生效的 *ini.File，Reload 时整份替换。用 atomic.Pointer 而不是
+// 裸指针是因为 persistRCloneBinds 会在管理API请求里并发读取它，裸指针赋值和
+// 并发读之间没有同步关系，-race 会报出数据竞争
+var cfgPtr atomic.Pointer[ini.File]
+
+// currentCfg 返回当前生效的 *ini.File
+func currentCfg() *ini.File { return cfgPtr.Load() }
+
+// confPath 记录配置文件路径，供绑定变更持久化回写时使用
+var confPath string
+
+// RCloneBindManager 管理运行时的 RClone 绑定，替代启动时一次性构建的 bindPoints。
+// 为 nil 表示当前系统未启用 RClone 绑定（未配置 Binds 或挂载后端选择失败）。
+var RCloneBindManager *manager.BindManager
+
+// CORSConfig/ThumbConfig/CaptchaConfig 是既有的包级变量，其余模块里已经有很多
+// 直接读取 conf.CORSConfig.XXX 字段的代码，这里沿用同样的变量形态，不改成函数。
+// 真正的原子替换落在下面的 corsPtr/thumbPtr/captchaPtr 上，setHotSwappableConfig
+// 负责把 Store 之后的值同步写回这三个变量；想要保证读到的是同一次 Reload 里
+// 一致的值，用 CurrentCORSConfig 等方法代替直接字段访问
+var (
+	CORSConfig    *cors
+	ThumbConfig   *thumb
+	CaptchaConfig *captcha
+)
+
+// corsPtr/thumbPtr/captchaPtr 存放可以在 Reload 时安全原子替换的分区，替换对
+// 正在处理中的请求没有影响，不像 Database/Listen 那样需要重启进程
+var (
+	corsPtr    atomic.Pointer[cors]
+	thumbPtr   atomic.Pointer[thumb]
+	captchaPtr atomic.Pointer[captcha]
+)
+
+// CurrentCORSConfig 原子返回当前生效的跨域配置
+func CurrentCORSConfig() *cors { return corsPtr.Load() }
+
+// CurrentThumbConfig 原子返回当前生效的缩略图配置
+func CurrentThumbConfig() *thumb { return thumbPtr.Load() }
+
+// CurrentCaptchaConfig 原子返回当前生效的验证码配置
+func CurrentCaptchaConfig() *captcha { return captchaPtr.Load() }
+
+// setHotSwappableConfig 原子替换 corsPtr/thumbPtr/captchaPtr，并同步更新
+// CORSConfig/ThumbConfig/CaptchaConfig 这三个兼容旧调用方式的包级变量
+func setHotSwappableConfig(newCors *cors, newThumb *thumb, newCaptcha *captcha) {
+	corsPtr.Store(newCors)
+	thumbPtr.Store(newThumb)
+	captchaPtr.Store(newCaptcha)
+	CORSConfig = newCors
+	ThumbConfig = newThumb
+	CaptchaConfig = newCaptcha
+}
+
+// restartRequiredSections 列出变更后必须重启进程才能生效的分区：
+// Database/SSL/UnixSocket/Slave/Redis 持有长连接或监听套接字，System 里的
+// Listen 同理，贸然替换会让正在使用的连接处于不一致状态
+var restartRequiredSections = []string{"Database", "System", "SSL", "UnixSocket", "Slave", "Redis"}
+
+// SensitiveKeys 列出 conf.ini 里已知承载敏感信息、"cloudreve conf encrypt/decrypt"
+// 默认处理的分区+键名。新增一类需要支持加密的配置时在这里补充即可
+var SensitiveKeys = [][2]string{
+	{"System", "SessionSecret"},
+	{"System", "HashIDSalt"},
+	{"Slave", "Secret"},
+	{"Database", "Password"},
+	{"Redis", "Password"},
+}
 
 const defaultConf = `[System]
 Mode = master
@@ -109,6 +189,8 @@ HashIDSalt = {HashIDSalt}
 func Init(path string) {
 	var err error
 
+	confPath = path
+
 	if path == "" || !util.Exists(path) {
 		// 创建初始配置文件
 		confContent := util.Replace(map[string]string{
@@ -129,20 +211,22 @@ func Init(path string) {
 		f.Close()
 	}
 
-	cfg, err = ini.Load(path)
+	loaded, err := ini.Load(path)
 	if err != nil {
 		util.Log().Panic("无法解析配置文件 '%s': %s", path, err)
 	}
 
+	if err = decryptSections(loaded); err != nil {
+		util.Log().Panic("配置文件加密字段解密失败: %s", err)
+	}
+	cfgPtr.Store(loaded)
+
 	sections := map[string]interface{}{
 		"Database":   DatabaseConfig,
 		"System":     SystemConfig,
 		"SSL":        SSLConfig,
 		"UnixSocket": UnixConfig,
-		"Captcha":    CaptchaConfig,
 		"Redis":      RedisConfig,
-		"Thumbnail":  ThumbConfig,
-		"CORS":       CORSConfig,
 		"RClone":     RCloneConfig,
 		"Slave":      SlaveConfig,
 	}
@@ -153,6 +237,12 @@ func Init(path string) {
 		}
 	}
 
+	// CORS/Thumbnail/Captcha 可以在 Reload 时原子替换，因此单独加载到
+	// atomic.Pointer 里，而不是放进上面那批一次性解析的分区
+	if err = loadHotSwappableSections(); err != nil {
+		util.Log().Panic("配置文件分区解析失败: %s", err)
+	}
+
 	// 重设log等级
 	if !SystemConfig.Debug {
 		util.Level = util.LevelInformational
@@ -165,7 +255,7 @@ func Init(path string) {
 
 // mapSection 将配置文件的 Section 映射到结构体上
 func mapSection(section string, confStruct interface{}) error {
-	err := cfg.Section(section).MapTo(confStruct)
+	err := currentCfg().Section(section).MapTo(confStruct)
 	if err != nil {
 		return err
 	}
@@ -180,40 +270,335 @@ func mapSection(section string, confStruct interface{}) error {
 	return nil
 }
 
+// decryptSections 扫描 cfg 中所有形如 "enc:<base64>" 的值并原地解密替换，解密
+// 后 mapSection 看到的就是明文，不需要再感知加密这件事。整份配置文件里不存在
+// 任何 enc: 值时直接跳过，不会要求输入口令——这是一项完全opt-in的功能
+func decryptSections(cfg *ini.File) error {
+	var hasEncrypted bool
+	for _, section := range cfg.Sections() {
+		for _, key := range section.Keys() {
+			if strings.HasPrefix(key.Value(), secret.Prefix) {
+				hasEncrypted = true
+			}
+		}
+	}
+	if !hasEncrypted {
+		return nil
+	}
+
+	passphrase, err := secret.ResolvePassphrase()
+	if err != nil {
+		return err
+	}
+
+	salt, err := secret.LoadOrCreateSalt(confPath)
+	if err != nil {
+		return err
+	}
+	key := secret.DeriveKey(passphrase, salt)
+
+	for _, section := range cfg.Sections() {
+		for _, k := range section.Keys() {
+			if !strings.HasPrefix(k.Value(), secret.Prefix) {
+				continue
+			}
+
+			plain, err := secret.Decrypt(strings.TrimPrefix(k.Value(), secret.Prefix), key)
+			if err != nil {
+				return fmt.Errorf("分区 '%s' 的 '%s' 解密失败: %w", section.Name(), k.Name(), err)
+			}
+			k.SetValue(plain)
+		}
+	}
+
+	// rclone 自己的 --config 也可能是用 rclone config 命令加密过的文件，这里把
+	// 同一份口令透传给它，这样 RClone.Config 引用的文件也可以是加密状态
+	os.Setenv("RCLONE_CONFIG_PASS", passphrase)
+	return nil
+}
+
+// loadHotSwappableSections 解析 CORS/Thumbnail/Captcha 三个分区并整体替换
+// corsPtr/thumbPtr/captchaPtr，任一分区解析失败都不会影响其余两个已生效的值
+func loadHotSwappableSections() error {
+	newCors := &cors{}
+	if err := mapSection("CORS", newCors); err != nil {
+		return err
+	}
+
+	newThumb := &thumb{}
+	if err := mapSection("Thumbnail", newThumb); err != nil {
+		return err
+	}
+
+	newCaptcha := &captcha{}
+	if err := mapSection("Captcha", newCaptcha); err != nil {
+		return err
+	}
+
+	setHotSwappableConfig(newCors, newThumb, newCaptcha)
+	return nil
+}
+
+// sectionChanged 将配置文件中 name 分区的最新内容与当前生效的值比较，判断
+// 是否发生了变化。只用于 restartRequiredSections 里列出的、不支持热替换的分区
+func sectionChanged(name string) (bool, error) {
+	switch name {
+	case "Database":
+		var tmp database
+		if err := mapSection(name, &tmp); err != nil {
+			return false, err
+		}
+		return !reflect.DeepEqual(tmp, *DatabaseConfig), nil
+	case "System":
+		var tmp system
+		if err := mapSection(name, &tmp); err != nil {
+			return false, err
+		}
+		return !reflect.DeepEqual(tmp, *SystemConfig), nil
+	case "SSL":
+		var tmp ssl
+		if err := mapSection(name, &tmp); err != nil {
+			return false, err
+		}
+		return !reflect.DeepEqual(tmp, *SSLConfig), nil
+	case "UnixSocket":
+		var tmp unix
+		if err := mapSection(name, &tmp); err != nil {
+			return false, err
+		}
+		return !reflect.DeepEqual(tmp, *UnixConfig), nil
+	case "Slave":
+		var tmp slave
+		if err := mapSection(name, &tmp); err != nil {
+			return false, err
+		}
+		return !reflect.DeepEqual(tmp, *SlaveConfig), nil
+	case "Redis":
+		var tmp redis
+		if err := mapSection(name, &tmp); err != nil {
+			return false, err
+		}
+		return !reflect.DeepEqual(tmp, *RedisConfig), nil
+	default:
+		return false, nil
+	}
+}
+
+// Reload 重新解析 path 指向的配置文件并应用变更：
+//   - CORS/Thumbnail/Captcha 通过 loadHotSwappableSections 原子替换，立即生效
+//   - RClone.Binds 的增删及选项变化通过 BindManager 做差量挂载/卸载
+//   - restartRequiredSections 里的分区一旦变化只记录警告日志，不做任何替换，
+//     避免让数据库连接池、监听套接字等处于不一致状态
+func Reload(path string) error {
+	newCfg, err := ini.Load(path)
+	if err != nil {
+		return fmt.Errorf("无法解析配置文件 '%s': %w", path, err)
+	}
+
+	if err = decryptSections(newCfg); err != nil {
+		return fmt.Errorf("配置文件加密字段解密失败: %w", err)
+	}
+
+	previous := cfgPtr.Load()
+	cfgPtr.Store(newCfg)
+	defer func() {
+		if err != nil {
+			cfgPtr.Store(previous)
+		}
+	}()
+
+	for _, section := range restartRequiredSections {
+		changed, changeErr := sectionChanged(section)
+		if changeErr != nil {
+			err = fmt.Errorf("配置文件 %s 分区解析失败: %w", section, changeErr)
+			return err
+		}
+		if changed {
+			util.Log().Warning("配置分区 '%s' 已变更，需要重启 Cloudreve 才能生效", section)
+		}
+	}
+
+	if err = loadHotSwappableSections(); err != nil {
+		return fmt.Errorf("配置文件分区解析失败: %w", err)
+	}
+
+	if err = ReloadRCloneBinds(); err != nil && !errors.Is(err, ErrRCloneBindDisabled) {
+		return fmt.Errorf("配置文件 RClone 分区解析失败: %w", err)
+	}
+	err = nil
+
+	util.Log().Info("配置文件 '%s' 已重新加载", path)
+	return nil
+}
+
+// ReloadCurrent 使用 Init 时记录下来的配置文件路径重新加载配置，供信号/管道
+// 等没有显式路径的触发方式使用
+func ReloadCurrent() error {
+	if confPath == "" {
+		return errors.New("尚未初始化配置文件路径")
+	}
+	return Reload(confPath)
+}
 
-func initRCloneBind(){
-	if runtime.GOOS != "linux"{
-		util.Log().Warning("RClone Bind Unsupported OS %s until tested", runtime.GOOS)
+// forwardRCloneConfigPassFromEnv 把 CLOUDREVE_CONF_PASSPHRASE 转发成
+// RCLONE_CONFIG_PASS，让rclone自己也能解密一份单独加密过的RClone.Config文件。
+// 这段口令转发和conf.ini本身是否用到了enc:值完全独立——conf.ini继续用明文，
+// 只给RClone.Config加密的部署也应该被覆盖到；decryptSections里设置过
+// RCLONE_CONFIG_PASS时这里直接跳过，不会覆盖掉已经解析出来的口令。只读环境
+// 变量、不做交互式提示，避免没用到这个功能的部署被要求输入口令
+func forwardRCloneConfigPassFromEnv() {
+	if RCloneConfig.Config == "" {
+		return
+	}
+	if _, alreadySet := os.LookupEnv("RCLONE_CONFIG_PASS"); alreadySet {
 		return
 	}
+	if p, ok := os.LookupEnv(secret.EnvPassphrase); ok && p != "" {
+		os.Setenv("RCLONE_CONFIG_PASS", p)
+	}
+}
 
-	if RCloneConfig.Binds[0] == "UNSET"{
+func initRCloneBind() {
+	forwardRCloneConfigPassFromEnv()
+
+	if RCloneConfig.Binds[0] == "UNSET" {
 		return
 	}
 
-	if ok := util.Exists(RCloneConfig.Config); ok{
+	if ok := util.Exists(RCloneConfig.Config); ok {
 		_ = rclonefs.SetConfigPath(RCloneConfig.Config)
-	}else{
+	} else {
 		util.Log().Warning("未找到RClone配置文件: %s", RCloneConfig.Config)
 		return
 	}
 
-	bindPoints := make(map[string]afero.Fs)
-	bindPoints["/"] = afero.NewOsFs()
+	mountBackend, err := backend.Select(RCloneConfig.Backend)
+	if err != nil {
+		util.Log().Warning("选择RClone挂载后端失败: %s", err)
+		return
+	}
+	util.Log().Info("RClone挂载后端: '%s'", mountBackend.Name())
 
-	for _, kv := range RCloneConfig.Binds{
-		if bind := strings.SplitN(kv,":", 2); len(bind)==2{
-			util.Log().Info("RClone绑定: '%s' -> '%s'", bind[1], bind[0])
-			if target, err := filepath.Abs(bind[0]); err==nil{
-				bindPoints[target] = rclonefs.NewRCloneFs(bind[1])
-			}else{
-				util.Log().Warning("绑定绝对路径出错: '%s'", bind[0])
-			}
-		}else{
-			util.Log().Warning("RClone绑定不符合格式: %s", kv)
-			return
+	RCloneBindManager = manager.NewBindManager(mountBackend)
+	for _, spec := range parseBindSpecs(RCloneConfig.Binds) {
+		if err := RCloneBindManager.Add(spec.Target, spec.Remote, spec.Opts); err != nil {
+			util.Log().Warning("RClone绑定 '%s' 挂载失败: %s", spec.Target, err)
+		}
+	}
+
+	util.OS = manager.NewManagedBindFs(RCloneBindManager, afero.NewOsFs())
+}
+
+// parseBindSpecs 解析 RClone.Binds 中的每一条 "target:remote[:opts]" 记录
+func parseBindSpecs(binds []string) []manager.Bind {
+	specs := make([]manager.Bind, 0, len(binds))
+
+	for _, kv := range binds {
+		targetRaw, remote, opts, err := bind.ParseSpec(kv)
+		if err != nil {
+			util.Log().Warning(err.Error())
+			continue
+		}
+
+		target, err := filepath.Abs(targetRaw)
+		if err != nil {
+			util.Log().Warning("绑定绝对路径出错: '%s'", targetRaw)
+			continue
+		}
+
+		specs = append(specs, manager.Bind{Target: target, Remote: remote, Opts: opts})
+	}
+
+	return specs
+}
+
+// persistRCloneBinds 将 RCloneBindManager 当前的绑定列表写回配置文件的
+// RClone.Binds 项，供下方的管理函数在 Add/Remove 后调用
+func persistRCloneBinds() error {
+	if RCloneBindManager == nil || confPath == "" {
+		return nil
+	}
+
+	binds := RCloneBindManager.List()
+	values := make([]string, 0, len(binds))
+	for _, b := range binds {
+		spec := b.Target + ":" + b.Remote
+		if opts := b.Opts.String(); opts != "" {
+			spec += ":" + opts
 		}
+		values = append(values, spec)
+	}
+
+	current := currentCfg()
+	current.Section("RClone").Key("Binds").SetValue(strings.Join(values, ","))
+	return current.SaveTo(confPath)
+}
+
+// ListRCloneBinds 返回当前生效的 RClone 绑定，供管理 API 使用
+func ListRCloneBinds() []manager.Bind {
+	if RCloneBindManager == nil {
+		return []manager.Bind{}
+	}
+	return RCloneBindManager.List()
+}
+
+// AddRCloneBind 新增一个 target:remote 绑定并持久化到配置文件，optsRaw 为
+// "ro,cache=full,..." 形式的可选挂载参数，留空则使用默认值。target 会和
+// parseBindSpecs 一样被规整成绝对路径，否则通过管理 API 传入的相对路径会和
+// ManagedBindFs.resolve 比较时用的绝对路径对不上
+func AddRCloneBind(target, remote, optsRaw string) error {
+	if RCloneBindManager == nil {
+		return ErrRCloneBindDisabled
 	}
 
-	util.OS = rclonefs.NewBindPathFs(bindPoints)
-}
\ No newline at end of file
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return fmt.Errorf("绑定绝对路径出错: '%s'", target)
+	}
+
+	opts, err := bind.ParseOptions(optsRaw)
+	if err != nil {
+		return err
+	}
+
+	if err := RCloneBindManager.Add(absTarget, remote, opts); err != nil {
+		return err
+	}
+
+	return persistRCloneBinds()
+}
+
+// RemoveRCloneBind 卸载 target 处的绑定并持久化到配置文件，target 同样需要
+// 规整成绝对路径才能匹配到 Add 时存入的 key
+func RemoveRCloneBind(target string) error {
+	if RCloneBindManager == nil {
+		return ErrRCloneBindDisabled
+	}
+
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return fmt.Errorf("绑定绝对路径出错: '%s'", target)
+	}
+
+	if err := RCloneBindManager.Remove(absTarget); err != nil {
+		return err
+	}
+
+	return persistRCloneBinds()
+}
+
+// ReloadRCloneBinds 重新解析配置文件中的 RClone.Binds 并应用到运行中的
+// BindManager，新增的绑定被挂载，移除的绑定被卸载
+func ReloadRCloneBinds() error {
+	if RCloneBindManager == nil {
+		return ErrRCloneBindDisabled
+	}
+
+	if err := mapSection("RClone", RCloneConfig); err != nil {
+		return err
+	}
+	forwardRCloneConfigPassFromEnv()
+
+	RCloneBindManager.Reload(parseBindSpecs(RCloneConfig.Binds))
+	return nil
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package conf
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/util"
+)
+
+// WatchReload 监听 SIGHUP，收到信号后调用 ReloadCurrent 热重载配置文件，
+// 替代此前"改配置必须重启进程"的做法
+func WatchReload() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+
+	go func() {
+		for range ch {
+			util.Log().Info("收到 SIGHUP，正在重新加载配置文件")
+			if err := ReloadCurrent(); err != nil {
+				util.Log().Warning("配置文件重新加载失败: %s", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,41 @@
+//go:build windows
+
+package conf
+
+import (
+	"bufio"
+
+	"github.com/Microsoft/go-winio"
+	"github.com/cloudreve/Cloudreve/v3/pkg/util"
+)
+
+// reloadPipeName 是 Windows 下触发热重载所监听的命名管道，Unix 下用 SIGHUP，
+// Windows 没有等价的信号，改用管道，任意一次写入都会触发一次重新加载
+const reloadPipeName = `\\.\pipe\cloudreve-reload`
+
+// WatchReload 监听 reloadPipeName，收到写入后调用 ReloadCurrent 热重载配置文件
+func WatchReload() {
+	listener, err := winio.ListenPipe(reloadPipeName, nil)
+	if err != nil {
+		util.Log().Warning("创建配置重载管道失败: %s", err)
+		return
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				util.Log().Warning("接受配置重载管道连接失败: %s", err)
+				continue
+			}
+
+			bufio.NewReader(conn).ReadString('\n')
+			conn.Close()
+
+			util.Log().Info("收到配置重载管道信号，正在重新加载配置文件")
+			if err := ReloadCurrent(); err != nil {
+				util.Log().Warning("配置文件重新加载失败: %s", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,138 @@
+// Package secret 提供 conf.ini 中 "enc:<base64>" 形式敏感值的加解密原语：
+// argon2id 从口令派生 AES-256-GCM 密钥，盐值存放在配置文件同目录的 conf.key 中。
+// 这里只放通用的密码学操作，哪些分区/键属于敏感信息由 conf 包决定。
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/term"
+)
+
+// Prefix 标记一个 ini 值是经过加密的，原文以 "enc:" 开头后面跟 base64 密文
+const Prefix = "enc:"
+
+// EnvPassphrase 是解密口令的环境变量名，未设置且 stdin 是终端时会转为交互式提示
+const EnvPassphrase = "CLOUDREVE_CONF_PASSPHRASE"
+
+const (
+	saltSize = 16
+	keySize  = 32
+
+	argonTime    = 1
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+)
+
+// saltPath 返回 confPath 同目录下的盐文件路径
+func saltPath(confPath string) string {
+	return filepath.Join(filepath.Dir(confPath), "conf.key")
+}
+
+// LoadOrCreateSalt 读取 confPath 同目录下 conf.key 中的盐值，不存在时生成一个
+// 随机盐并以 0600 权限写入，保证同一份配置文件多次加解密用的是同一个盐
+func LoadOrCreateSalt(confPath string) ([]byte, error) {
+	path := saltPath(confPath)
+
+	if b, err := os.ReadFile(path); err == nil {
+		return b, nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("无法读取盐文件 '%s': %w", path, err)
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("生成盐失败: %w", err)
+	}
+
+	if err := os.WriteFile(path, salt, 0600); err != nil {
+		return nil, fmt.Errorf("无法写入盐文件 '%s': %w", path, err)
+	}
+
+	return salt, nil
+}
+
+// DeriveKey 用 argon2id 从口令和盐派生出 AES-256-GCM 所需的密钥
+func DeriveKey(passphrase string, salt []byte) []byte {
+	return argon2.IDKey([]byte(passphrase), salt, argonTime, argonMemory, argonThreads, keySize)
+}
+
+// Encrypt 用 AES-256-GCM 加密 plaintext，返回不带 Prefix 的 base64 密文
+func Encrypt(plaintext string, key []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("生成nonce失败: %w", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 是 Encrypt 的逆操作，ciphertext 不带 Prefix
+func Decrypt(ciphertext string, key []byte) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("密文base64解码失败: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(raw) < gcm.NonceSize() {
+		return "", errors.New("密文长度不合法")
+	}
+
+	nonce, sealed := raw[:gcm.NonceSize()], raw[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("解密失败，口令可能不正确: %w", err)
+	}
+
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ResolvePassphrase 优先读取 EnvPassphrase 环境变量，未设置且 stdin 是终端时
+// 交互式提示输入；两者都不满足时返回错误，调用方只应在确实需要口令（配置文件
+// 中存在 enc: 值，或明确要加密）时才调用这个函数
+func ResolvePassphrase() (string, error) {
+	if p, ok := os.LookupEnv(EnvPassphrase); ok && p != "" {
+		return p, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("未设置环境变量 %s 且当前不在交互式终端中，无法获取配置解密口令", EnvPassphrase)
+	}
+
+	fmt.Fprint(os.Stderr, "请输入配置解密口令: ")
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("读取口令失败: %w", err)
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,68 @@
+package secret
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	key := DeriveKey("test-passphrase", []byte("0123456789abcdef"))
+
+	plain := "s3cr3t-value"
+	cipherText, err := Encrypt(plain, key)
+	if err != nil {
+		t.Fatalf("Encrypt returned error: %v", err)
+	}
+
+	got, err := Decrypt(cipherText, key)
+	if err != nil {
+		t.Fatalf("Decrypt returned error: %v", err)
+	}
+	if got != plain {
+		t.Fatalf("round-trip mismatch: got %q, want %q", got, plain)
+	}
+}
+
+func TestDecryptWrongKeyFails(t *testing.T) {
+	key := DeriveKey("right", []byte("0123456789abcdef"))
+	wrongKey := DeriveKey("wrong", []byte("0123456789abcdef"))
+
+	cipherText, err := Encrypt("value", key)
+	if err != nil {
+		t.Fatalf("Encrypt returned error: %v", err)
+	}
+
+	if _, err := Decrypt(cipherText, wrongKey); err == nil {
+		t.Fatal("expected error decrypting with the wrong key")
+	}
+}
+
+func TestLoadOrCreateSaltPersists(t *testing.T) {
+	dir := t.TempDir()
+	confPath := filepath.Join(dir, "conf.ini")
+
+	salt1, err := LoadOrCreateSalt(confPath)
+	if err != nil {
+		t.Fatalf("LoadOrCreateSalt returned error: %v", err)
+	}
+	if len(salt1) != saltSize {
+		t.Fatalf("unexpected salt length: %d", len(salt1))
+	}
+
+	salt2, err := LoadOrCreateSalt(confPath)
+	if err != nil {
+		t.Fatalf("LoadOrCreateSalt returned error: %v", err)
+	}
+	if string(salt1) != string(salt2) {
+		t.Fatal("second call returned a different salt than the first")
+	}
+
+	info, err := os.Stat(saltPath(confPath))
+	if err != nil {
+		t.Fatalf("conf.key was not created: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("unexpected conf.key permissions: %v", perm)
+	}
+}
@@ -0,0 +1,30 @@
+package backend
+
+import (
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/bind"
+	"github.com/spf13/afero"
+)
+
+// AferoBackend 是原先 initRCloneBind 使用的方案：不做真正的系统挂载，直接把
+// rclone operations 包一层 afero.Fs 供 util.OS 消费。纯 Go 实现，不依赖任何
+// 平台相关的 FUSE/NFS 组件，是 Linux 下的默认后端；缺点是不经过完整的 rclone VFS，见
+// bind.New 的文档说明哪些挂载选项在这个后端下不生效
+type AferoBackend struct{}
+
+// NewAferoBackend 构建 AferoBackend
+func NewAferoBackend() *AferoBackend {
+	return &AferoBackend{}
+}
+
+func (b *AferoBackend) Name() string {
+	return "afero"
+}
+
+func (b *AferoBackend) Mount(target, remote string, opts bind.Options) (afero.Fs, error) {
+	return bind.New(remote, opts)
+}
+
+// Unmount 对 AferoBackend 而言只是把引用丢弃，没有外部资源需要释放
+func (b *AferoBackend) Unmount(target string) error {
+	return nil
+}
@@ -0,0 +1,54 @@
+package backend
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/bind"
+	"github.com/spf13/afero"
+)
+
+// MountBackend 把一个 RClone remote 挂载为 target 目录下可用的 afero.Fs。
+// Linux 下沿用现有的 afero 绑定方案，Windows/macOS 下由实现自行拉起对应的
+// FUSE/NFS 挂载进程。selected by runtime.GOOS or RClone.Backend 配置。
+type MountBackend interface {
+	// Name 返回后端标识，与 RClone.Backend 配置值一致
+	Name() string
+	// Mount 挂载 remote 到 target（绝对路径），返回一个代表该目录树的 afero.Fs
+	Mount(target, remote string, opts bind.Options) (afero.Fs, error)
+	// Unmount 释放 Mount 期间分配的资源（子进程、挂载点等）
+	Unmount(target string) error
+}
+
+// Select 根据配置的后端名字返回对应的 MountBackend 实现，name 为空或 "auto"
+// 时按 runtime.GOOS 自动选择
+func Select(name string) (MountBackend, error) {
+	if name == "" || name == "auto" {
+		name = defaultBackendName()
+	}
+
+	switch name {
+	case "afero":
+		return NewAferoBackend(), nil
+	case "fuse":
+		return NewFuseBackend()
+	case "nfs":
+		return NewServeBackend("nfs"), nil
+	case "webdav":
+		return NewServeBackend("webdav"), nil
+	default:
+		return nil, fmt.Errorf("未知的RClone挂载后端: %s", name)
+	}
+}
+
+// defaultBackendName 在未显式配置 RClone.Backend 时，按平台给出一个最常用的默认值
+func defaultBackendName() string {
+	switch runtime.GOOS {
+	case "linux":
+		return "afero"
+	case "windows", "darwin":
+		return "fuse"
+	default:
+		return "nfs"
+	}
+}
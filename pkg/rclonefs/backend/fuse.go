@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/bind"
+	"github.com/cloudreve/Cloudreve/v3/pkg/util"
+	"github.com/spf13/afero"
+)
+
+// FuseBackend 通过拉起 `rclone mount` 子进程完成真正的系统级挂载。rclone 自身
+// 基于 cgofuse 在 Windows 下对接 WinFsp、在 macOS 下对接 macFUSE、在 Linux 下
+// 对接 libfuse，因此这里不需要区分平台，只需要把 bind.Options 翻译成对应的
+// command line flag
+type FuseBackend struct {
+	mu    sync.Mutex
+	procs map[string]*exec.Cmd
+}
+
+// NewFuseBackend 构建 FuseBackend，确认本机存在可用的 rclone 可执行文件
+func NewFuseBackend() (MountBackend, error) {
+	if _, err := exec.LookPath("rclone"); err != nil {
+		return nil, fmt.Errorf("未找到rclone可执行文件，无法使用fuse挂载后端: %w", err)
+	}
+
+	return &FuseBackend{procs: make(map[string]*exec.Cmd)}, nil
+}
+
+func (b *FuseBackend) Name() string {
+	return "fuse"
+}
+
+func (b *FuseBackend) Mount(target, remote string, opts bind.Options) (afero.Fs, error) {
+	args := []string{"mount", remote, target,
+		"--vfs-cache-mode", opts.CacheMode,
+		"--poll-interval", opts.PollInterval.String(),
+		"--vfs-read-chunk-size", opts.ChunkSize.String(),
+		"--vfs-cache-max-size", opts.MaxCacheSize.String(),
+		"--transfers", fmt.Sprintf("%d", opts.UploadConcurrency),
+	}
+	if opts.ReadOnly {
+		args = append(args, "--read-only")
+	}
+
+	// 故意不加 --daemon：rclone mount 加上它会二次fork，我们这里持有的
+	// cmd.Process 就只是已经退出的父进程，Unmount 时 Kill 它既杀不死真正挂载
+	// 的那个进程，也没法靠 cmd.Wait() 回收资源。让它以前台进程方式运行，
+	// cmd.Process 才是真正做挂载的那个
+	cmd := exec.Command("rclone", args...)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("启动rclone mount失败: %w", err)
+	}
+	go reapProcess("rclone mount", target, cmd)
+
+	if err := waitForMount(target, 10*time.Second); err != nil {
+		_ = cmd.Process.Kill()
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.procs[target] = cmd
+	b.mu.Unlock()
+
+	util.Log().Info("RClone绑定(fuse): '%s' -> '%s'", remote, target)
+	return afero.NewBasePathFs(afero.NewOsFs(), target), nil
+}
+
+func (b *FuseBackend) Unmount(target string) error {
+	b.mu.Lock()
+	cmd, ok := b.procs[target]
+	delete(b.procs, target)
+	b.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	if err := unmountPath(target); err != nil {
+		util.Log().Warning("卸载 '%s' 时出错，将强制结束rclone mount进程: %s", target, err)
+	}
+
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,34 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/util"
+)
+
+// waitForMount 轮询 target 直到它变成一个可访问的目录，或者超时。rclone mount
+// 以前台进程方式运行时，真正完成挂载之前目录还不能被访问，所以需要轮询等待
+func waitForMount(target string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if info, err := os.Stat(target); err == nil && info.IsDir() {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return fmt.Errorf("等待挂载点 '%s' 就绪超时", target)
+}
+
+// reapProcess 阻塞等待 cmd 退出并回收它，FuseBackend/ServeBackend 都不会给
+// 子进程加 --daemon（会二次fork导致 cmd.Process 指向已经退出的父进程，既杀不
+// 死真正挂载的进程，也会在它退出后留下僵尸），所以这里的 cmd 就是真正挂载/
+// serve 的那个进程，Unmount 时被 kill 也会经过这里被 Wait 收割
+func reapProcess(name, target string, cmd *exec.Cmd) {
+	if err := cmd.Wait(); err != nil {
+		util.Log().Info("%s进程（挂载点 '%s'）已退出: %s", name, target, err)
+	}
+}
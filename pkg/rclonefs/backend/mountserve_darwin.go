@@ -0,0 +1,26 @@
+package backend
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+)
+
+// mountServe 在 macOS 下用 mount_nfs/mount_webdav 挂载 rclone serve 暴露的服务。
+// addr 是 loopbackAddr 分配的随机端口，必须解析出实际端口传给 port=/mountport=，
+// 不能沿用rclone serve nfs默认的2049
+func mountServe(protocol, addr, target string) error {
+	switch protocol {
+	case "nfs":
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return fmt.Errorf("解析rclone serve监听地址 '%s' 失败: %w", addr, err)
+		}
+		opt := fmt.Sprintf("vers=3,tcp,port=%s,mountport=%s", port, port)
+		return exec.Command("mount_nfs", "-o", opt, host+":/", target).Run()
+	case "webdav":
+		return exec.Command("mount_webdav", "http://"+addr+"/", target).Run()
+	default:
+		return fmt.Errorf("未知的serve协议: %s", protocol)
+	}
+}
@@ -0,0 +1,27 @@
+package backend
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+)
+
+// mountServe 在 Linux 下用原生 mount(8) 挂载 rclone serve 暴露的 NFS/WebDAV 服务。
+// WebDAV 经由 davfs2 提供的 mount.davfs 辅助程序挂载。addr 是 loopbackAddr 分配
+// 的随机端口（"127.0.0.1:port"），不是rclone serve nfs默认监听的2049端口，所以
+// 必须从 addr 里把实际端口解析出来传给 -o port=，不能沿用旧的固定2049
+func mountServe(protocol, addr, target string) error {
+	switch protocol {
+	case "nfs":
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return fmt.Errorf("解析rclone serve监听地址 '%s' 失败: %w", addr, err)
+		}
+		opt := fmt.Sprintf("port=%s,mountport=%s,nolock", port, port)
+		return exec.Command("mount", "-t", "nfs", "-o", opt, host+":/", target).Run()
+	case "webdav":
+		return exec.Command("mount", "-t", "davfs", "http://"+addr+"/", target).Run()
+	default:
+		return fmt.Errorf("未知的serve协议: %s", protocol)
+	}
+}
@@ -0,0 +1,16 @@
+package backend
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// mountServe 在 Windows 下用 net use 把 rclone serve 暴露的 WebDAV 服务映射为
+// 本地路径；Windows 缺乏开箱即用的 NFS 客户端挂载工具，因此只支持 webdav
+func mountServe(protocol, addr, target string) error {
+	if protocol != "webdav" {
+		return fmt.Errorf("Windows下serve后端仅支持webdav，收到: %s", protocol)
+	}
+
+	return exec.Command("net", "use", target, "http://"+addr+"/").Run()
+}
@@ -0,0 +1,116 @@
+package backend
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/bind"
+	"github.com/cloudreve/Cloudreve/v3/pkg/util"
+	"github.com/spf13/afero"
+)
+
+// ServeBackend 面向不具备 FUSE 能力的环境：拉起 `rclone serve nfs`/`rclone
+// serve webdav` 监听在回环地址上的随机端口，再用操作系统自带的 NFS/WebDAV
+// 客户端把它挂载为本地目录。protocol 取值 "nfs" 或 "webdav"
+type ServeBackend struct {
+	protocol string
+
+	mu    sync.Mutex
+	procs map[string]*exec.Cmd
+}
+
+// NewServeBackend 构建一个以 protocol 对外提供服务的 ServeBackend
+func NewServeBackend(protocol string) *ServeBackend {
+	return &ServeBackend{protocol: protocol, procs: make(map[string]*exec.Cmd)}
+}
+
+func (b *ServeBackend) Name() string {
+	return b.protocol
+}
+
+func (b *ServeBackend) Mount(target, remote string, opts bind.Options) (afero.Fs, error) {
+	addr, err := loopbackAddr()
+	if err != nil {
+		return nil, fmt.Errorf("分配本地端口失败: %w", err)
+	}
+
+	args := []string{"serve", b.protocol, remote,
+		"--addr", addr,
+		"--vfs-cache-mode", opts.CacheMode,
+		"--poll-interval", opts.PollInterval.String(),
+		"--vfs-read-chunk-size", opts.ChunkSize.String(),
+		"--vfs-cache-max-size", opts.MaxCacheSize.String(),
+	}
+	if opts.ReadOnly {
+		args = append(args, "--read-only")
+	}
+
+	cmd := exec.Command("rclone", args...)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("启动rclone serve %s失败: %w", b.protocol, err)
+	}
+	go reapProcess("rclone serve "+b.protocol, target, cmd)
+
+	if err := waitForServe(addr, 10*time.Second); err != nil {
+		_ = cmd.Process.Kill()
+		return nil, err
+	}
+
+	if err := mountServe(b.protocol, addr, target); err != nil {
+		_ = cmd.Process.Kill()
+		return nil, fmt.Errorf("挂载 %s 服务到 '%s' 失败: %w", b.protocol, target, err)
+	}
+
+	b.mu.Lock()
+	b.procs[target] = cmd
+	b.mu.Unlock()
+
+	util.Log().Info("RClone绑定(%s): '%s' -> '%s' (通过 %s)", b.protocol, remote, target, addr)
+	return afero.NewBasePathFs(afero.NewOsFs(), target), nil
+}
+
+func (b *ServeBackend) Unmount(target string) error {
+	b.mu.Lock()
+	cmd, ok := b.procs[target]
+	delete(b.procs, target)
+	b.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	if err := unmountPath(target); err != nil {
+		util.Log().Warning("卸载 '%s' 时出错，将强制结束rclone serve进程: %s", target, err)
+	}
+
+	return cmd.Process.Kill()
+}
+
+// loopbackAddr 在回环地址上取一个空闲端口
+func loopbackAddr() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+
+	return l.Addr().String(), nil
+}
+
+// waitForServe 轮询 addr 直到可以建立 TCP 连接，或者超时
+func waitForServe(addr string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		conn, err := net.DialTimeout("tcp", addr, 200*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return fmt.Errorf("等待rclone serve监听 '%s' 超时", addr)
+}
@@ -0,0 +1,8 @@
+package backend
+
+import "os/exec"
+
+// unmountPath 在 macOS 下通过 umount 卸载 macFUSE 挂载点
+func unmountPath(target string) error {
+	return exec.Command("umount", target).Run()
+}
@@ -0,0 +1,8 @@
+package backend
+
+import "os/exec"
+
+// unmountPath 在 Linux 下通过 fusermount 卸载挂载点
+func unmountPath(target string) error {
+	return exec.Command("fusermount", "-u", target).Run()
+}
@@ -0,0 +1,7 @@
+package backend
+
+// unmountPath 在 Windows 下由 WinFsp 托管挂载点，结束 rclone mount 进程即会
+// 自动释放，这里无需额外的卸载命令
+func unmountPath(target string) error {
+	return nil
+}
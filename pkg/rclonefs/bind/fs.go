@@ -0,0 +1,55 @@
+package bind
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/util"
+	"github.com/spf13/afero"
+	"github.com/spf13/afero/rclonefs"
+)
+
+// New 依据 Options 构建绑定用的 afero.Fs。rclonefs.NewRCloneFs 只接受 remote
+// 一个参数，是直接把 afero 调用转译成 rclone operations，并不会实例化完整的
+// rclone VFS，因此这里只能真正让 ReadOnly、UploadConcurrency 生效；
+// CacheMode/PollInterval/ChunkSize/MaxCacheSize 这些 VFS 层面的选项只有在
+// backend.FuseBackend/ServeBackend（nfs/webdav）拉起 `rclone mount`/
+// `rclone serve` 子进程时才会真正生效（见 fuse.go、serve.go 里的命令行参数），
+// 用 afero 后端请求这些选项时只记录警告，不拒绝挂载
+func New(remote string, opts Options) (afero.Fs, error) {
+	warnUnsupportedVFSOptions(remote, opts)
+
+	var fs afero.Fs = rclonefs.NewRCloneFs(withUploadConcurrency(remote, opts))
+	if opts.ReadOnly {
+		fs = afero.NewReadOnlyFs(fs)
+	}
+
+	return fs, nil
+}
+
+// warnUnsupportedVFSOptions 提示 afero 后端无法生效的缓存类选项，避免用户
+// 以为 cache/poll/chunk/max-cache 配置已经对这个绑定生效
+func warnUnsupportedVFSOptions(remote string, opts Options) {
+	def := defaultOptions()
+	if opts.CacheMode != def.CacheMode || opts.PollInterval != def.PollInterval ||
+		opts.ChunkSize != def.ChunkSize || opts.MaxCacheSize != def.MaxCacheSize {
+		util.Log().Warning(
+			"RClone绑定 '%s' 使用afero后端，cache/poll/chunk/max-cache选项不会生效，如需这些选项请将RClone.Backend设为fuse/nfs/webdav",
+			remote)
+	}
+}
+
+// withUploadConcurrency 通过 rclone 连接字符串语法（"remote,transfers=N:path"）
+// 把每绑定的上传并发数下发给底层 remote，避免所有绑定共用同一个全局并发设置
+func withUploadConcurrency(remote string, opts Options) string {
+	if opts.UploadConcurrency <= 0 {
+		return remote
+	}
+
+	parts := strings.SplitN(remote, ":", 2)
+	name := fmt.Sprintf("%s,transfers=%d", parts[0], opts.UploadConcurrency)
+	if len(parts) == 2 {
+		return name + ":" + parts[1]
+	}
+	return name + ":"
+}
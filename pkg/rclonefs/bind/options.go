@@ -0,0 +1,145 @@
+package bind
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rclone/rclone/fs"
+)
+
+// Options 描述单条 Binds 记录里 "target:remote" 之后可选的挂载参数，
+// 例如 "ro,cache=full,poll=15s,chunk=32M,max-cache=10G,upload-concurrency=4"
+type Options struct {
+	ReadOnly          bool
+	CacheMode         string
+	PollInterval      time.Duration
+	ChunkSize         fs.SizeSuffix
+	MaxCacheSize      fs.SizeSuffix
+	UploadConcurrency int
+	// HealthInterval 是健康探测的周期，<=0 表示不对该绑定做健康检查/熔断
+	HealthInterval time.Duration
+}
+
+// defaultOptions 与 rclone mount 的默认值保持一致
+func defaultOptions() Options {
+	return Options{
+		CacheMode:         "off",
+		PollInterval:      time.Minute,
+		ChunkSize:         128 * fs.Mebi,
+		MaxCacheSize:      10 * fs.Gibi,
+		UploadConcurrency: 4,
+		HealthInterval:    30 * time.Second,
+	}
+}
+
+// ParseSpec 解析一条 Binds 记录，格式为 "target:remote" 或
+// "target:remote:opt1,opt2=val,..."，与 initRCloneBind 原先的 "target:remote"
+// 语法向下兼容
+func ParseSpec(spec string) (target, remote string, opts Options, err error) {
+	parts := strings.SplitN(spec, ":", 3)
+	if len(parts) < 2 {
+		return "", "", Options{}, fmt.Errorf("RClone绑定不符合格式: %s", spec)
+	}
+
+	opts = defaultOptions()
+	if len(parts) == 3 {
+		if opts, err = ParseOptions(parts[2]); err != nil {
+			return "", "", Options{}, fmt.Errorf("绑定选项解析失败 '%s': %w", spec, err)
+		}
+	}
+
+	return parts[0], parts[1], opts, nil
+}
+
+// ParseOptions 解析逗号分隔的选项串，例如
+// "ro,cache=full,poll=15s,chunk=32M,max-cache=10G,upload-concurrency=4"
+func ParseOptions(raw string) (Options, error) {
+	opts := defaultOptions()
+	if raw == "" {
+		return opts, nil
+	}
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		kv := strings.SplitN(item, "=", 2)
+		key := kv[0]
+		var value string
+		if len(kv) == 2 {
+			value = kv[1]
+		}
+
+		var err error
+		switch key {
+		case "ro":
+			opts.ReadOnly = true
+		case "cache":
+			switch value {
+			case "off", "minimal", "writes", "full":
+				opts.CacheMode = value
+			default:
+				return Options{}, fmt.Errorf("cache: 未知缓存模式 '%s'，可选 off|minimal|writes|full", value)
+			}
+		case "poll":
+			if opts.PollInterval, err = time.ParseDuration(value); err != nil {
+				return Options{}, fmt.Errorf("poll: %w", err)
+			}
+		case "chunk":
+			if err = opts.ChunkSize.Set(value); err != nil {
+				return Options{}, fmt.Errorf("chunk: %w", err)
+			}
+		case "max-cache":
+			if err = opts.MaxCacheSize.Set(value); err != nil {
+				return Options{}, fmt.Errorf("max-cache: %w", err)
+			}
+		case "upload-concurrency":
+			if opts.UploadConcurrency, err = strconv.Atoi(value); err != nil {
+				return Options{}, fmt.Errorf("upload-concurrency: %w", err)
+			}
+		case "health":
+			if opts.HealthInterval, err = time.ParseDuration(value); err != nil {
+				return Options{}, fmt.Errorf("health: %w", err)
+			}
+		default:
+			return Options{}, fmt.Errorf("未知绑定选项: %s", key)
+		}
+	}
+
+	return opts, nil
+}
+
+// String 将 Options 序列化为 "target:remote" 之后的那段逗号分隔选项，
+// 与 ParseSpec 互为逆操作，供持久化绑定列表回写配置文件时使用
+func (o Options) String() string {
+	def := defaultOptions()
+	items := make([]string, 0, 6)
+
+	if o.ReadOnly {
+		items = append(items, "ro")
+	}
+	if o.CacheMode != def.CacheMode {
+		items = append(items, "cache="+o.CacheMode)
+	}
+	if o.PollInterval != def.PollInterval {
+		items = append(items, "poll="+o.PollInterval.String())
+	}
+	if o.ChunkSize != def.ChunkSize {
+		items = append(items, "chunk="+o.ChunkSize.String())
+	}
+	if o.MaxCacheSize != def.MaxCacheSize {
+		items = append(items, "max-cache="+o.MaxCacheSize.String())
+	}
+	if o.UploadConcurrency != def.UploadConcurrency {
+		items = append(items, fmt.Sprintf("upload-concurrency=%d", o.UploadConcurrency))
+	}
+	if o.HealthInterval != def.HealthInterval {
+		items = append(items, "health="+o.HealthInterval.String())
+	}
+
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,62 @@
+package bind
+
+import "testing"
+
+func TestParseOptionsDefaults(t *testing.T) {
+	opts, err := ParseOptions("")
+	if err != nil {
+		t.Fatalf("ParseOptions(\"\") returned error: %v", err)
+	}
+	if opts != defaultOptions() {
+		t.Fatalf("expected default options, got %+v", opts)
+	}
+}
+
+func TestParseOptionsRoundTrip(t *testing.T) {
+	cases := []string{
+		"ro,cache=full,poll=15s,chunk=32M,max-cache=10G,upload-concurrency=8,health=1m",
+		"cache=minimal",
+		"health=0s",
+	}
+
+	for _, raw := range cases {
+		opts, err := ParseOptions(raw)
+		if err != nil {
+			t.Fatalf("ParseOptions(%q) returned error: %v", raw, err)
+		}
+
+		reparsed, err := ParseOptions(opts.String())
+		if err != nil {
+			t.Fatalf("ParseOptions(%q) (round-trip of %q) returned error: %v", opts.String(), raw, err)
+		}
+
+		if reparsed != opts {
+			t.Fatalf("round-trip mismatch for %q: got %+v, want %+v", raw, reparsed, opts)
+		}
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	target, remote, opts, err := ParseSpec("/mnt/ibm:ibm:ro,cache=full")
+	if err != nil {
+		t.Fatalf("ParseSpec returned error: %v", err)
+	}
+	if target != "/mnt/ibm" || remote != "ibm" {
+		t.Fatalf("unexpected target/remote: %q %q", target, remote)
+	}
+	if !opts.ReadOnly || opts.CacheMode != "full" {
+		t.Fatalf("unexpected opts: %+v", opts)
+	}
+}
+
+func TestParseOptionsUnknownKey(t *testing.T) {
+	if _, err := ParseOptions("bogus=1"); err == nil {
+		t.Fatal("expected error for unknown option key")
+	}
+}
+
+func TestParseOptionsInvalidCacheMode(t *testing.T) {
+	if _, err := ParseOptions("cache=nope"); err == nil {
+		t.Fatal("expected error for invalid cache mode")
+	}
+}
@@ -0,0 +1,304 @@
+package health
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/util"
+	"github.com/spf13/afero"
+)
+
+// ErrRemoteUnavailable 在熔断开启期间由 Open/Stat/... 等方法返回，上传/下载
+// 相关的 handler 可以据此给客户端返回 503 而不是一直阻塞等一个已经失联的 remote
+var ErrRemoteUnavailable = errors.New("rclone remote 暂不可用")
+
+// failureThreshold 是连续探测失败多少次后熔断，没有做成可配置项是因为目前
+// 还没有场景需要针对单个绑定调整它
+const failureThreshold = 3
+
+// State 描述一个绑定当前的健康状态
+type State string
+
+const (
+	StateHealthy  State = "healthy"
+	StateDegraded State = "degraded"
+	StateDown     State = "down"
+)
+
+// Status 是 /api/v3/admin/rclone/status 返回给管理员看的快照
+type Status struct {
+	State     State         `json:"state"`
+	LastError string        `json:"last_error,omitempty"`
+	LastProbe time.Time     `json:"last_probe"`
+	RTT       time.Duration `json:"rtt"`
+}
+
+// Rebuilder 重新构建一个绑定底层的 afero.Fs，熔断后的重连循环靠它拿到一个
+// 全新的连接，而不是反复重试同一个可能已经损坏的 fs
+type Rebuilder func() (afero.Fs, error)
+
+// HealthyFs 给一个 afero.Fs 包一层周期性探测 + 熔断 + 指数退避重连。探测用
+// Stat("/") 模拟 rclone 自己健康检查常用的 About/List 调用——足够轻量，同时能
+// 真正触发一次到 remote 的往返
+type HealthyFs struct {
+	remote   string
+	interval time.Duration
+	rebuild  Rebuilder
+	stopCh   chan struct{}
+
+	mu     sync.RWMutex
+	fs     afero.Fs
+	status Status
+	fails  int32
+}
+
+// New 构建一个 HealthyFs 并立即启动后台探测循环，调用方需要在绑定被移除时
+// 调用 Close 以停止 goroutine
+func New(remote string, interval time.Duration, fs afero.Fs, rebuild Rebuilder) *HealthyFs {
+	h := &HealthyFs{
+		remote:   remote,
+		interval: interval,
+		rebuild:  rebuild,
+		fs:       fs,
+		stopCh:   make(chan struct{}),
+		status:   Status{State: StateHealthy, LastProbe: time.Now()},
+	}
+
+	go h.loop()
+	return h
+}
+
+// Close 停止后台探测循环
+func (h *HealthyFs) Close() {
+	close(h.stopCh)
+}
+
+// Status 返回当前健康状态快照
+func (h *HealthyFs) Status() Status {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.status
+}
+
+func (h *HealthyFs) loop() {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	backoff := h.interval
+	for {
+		select {
+		case <-h.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		if h.probe() {
+			backoff = h.interval
+			continue
+		}
+
+		if atomic.LoadInt32(&h.fails) < failureThreshold {
+			continue
+		}
+
+		// 已经熔断，按指数退避尝试重建底层连接。退避期间也要能被 Close 打断，
+		// 否则 BindManager.Remove/Reload 在 Close 后立刻把同一个 target 挂载给
+		// 别的绑定时，这个还没退出的 goroutine 会在退避结束后用旧的
+		// remote/opts 再调一次 backend.Unmount/Mount，把新挂载的内容顶掉
+		if !h.interruptibleSleep(backoff) {
+			return
+		}
+		if err := h.reconnect(); err != nil {
+			backoff *= 2
+			if backoff > 5*time.Minute {
+				backoff = 5 * time.Minute
+			}
+			continue
+		}
+		backoff = h.interval
+	}
+}
+
+// interruptibleSleep 等待 d 或 stopCh 被关闭，返回 false 表示等待被 Close 提前
+// 打断，调用方应立即退出而不是继续走下去
+func (h *HealthyFs) interruptibleSleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	select {
+	case <-h.stopCh:
+		timer.Stop()
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// probe 做一次轻量探测，更新状态并返回是否成功
+func (h *HealthyFs) probe() bool {
+	h.mu.RLock()
+	fs := h.fs
+	h.mu.RUnlock()
+
+	start := time.Now()
+	_, err := fs.Stat("/")
+	rtt := time.Since(start)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.status.LastProbe = start
+	h.status.RTT = rtt
+
+	if err != nil {
+		h.status.LastError = err.Error()
+		fails := atomic.AddInt32(&h.fails, 1)
+		if fails >= failureThreshold {
+			if h.status.State != StateDown {
+				util.Log().Warning("RClone绑定 '%s' 连续 %d 次探测失败，进入熔断状态", h.remote, fails)
+			}
+			h.status.State = StateDown
+		} else {
+			h.status.State = StateDegraded
+		}
+		return false
+	}
+
+	if h.status.State != StateHealthy {
+		util.Log().Info("RClone绑定 '%s' 探测恢复正常", h.remote)
+	}
+	atomic.StoreInt32(&h.fails, 0)
+	h.status.State = StateHealthy
+	h.status.LastError = ""
+	return true
+}
+
+// reconnect 通过 Rebuilder 重新构建底层 fs，成功后原子替换并解除熔断
+func (h *HealthyFs) reconnect() error {
+	fs, err := h.rebuild()
+	if err != nil {
+		h.mu.Lock()
+		h.status.LastError = err.Error()
+		h.mu.Unlock()
+		return err
+	}
+
+	h.mu.Lock()
+	h.fs = fs
+	h.status.State = StateHealthy
+	h.status.LastError = ""
+	h.mu.Unlock()
+	atomic.StoreInt32(&h.fails, 0)
+
+	util.Log().Info("RClone绑定 '%s' 重连成功，已解除熔断", h.remote)
+	return nil
+}
+
+func (h *HealthyFs) current() (afero.Fs, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.status.State == StateDown {
+		return nil, ErrRemoteUnavailable
+	}
+	return h.fs, nil
+}
+
+func (h *HealthyFs) Open(name string) (afero.File, error) {
+	fs, err := h.current()
+	if err != nil {
+		return nil, err
+	}
+	return fs.Open(name)
+}
+
+func (h *HealthyFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	fs, err := h.current()
+	if err != nil {
+		return nil, err
+	}
+	return fs.OpenFile(name, flag, perm)
+}
+
+func (h *HealthyFs) Stat(name string) (os.FileInfo, error) {
+	fs, err := h.current()
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(name)
+}
+
+func (h *HealthyFs) Create(name string) (afero.File, error) {
+	fs, err := h.current()
+	if err != nil {
+		return nil, err
+	}
+	return fs.Create(name)
+}
+
+func (h *HealthyFs) Mkdir(name string, perm os.FileMode) error {
+	fs, err := h.current()
+	if err != nil {
+		return err
+	}
+	return fs.Mkdir(name, perm)
+}
+
+func (h *HealthyFs) MkdirAll(path string, perm os.FileMode) error {
+	fs, err := h.current()
+	if err != nil {
+		return err
+	}
+	return fs.MkdirAll(path, perm)
+}
+
+func (h *HealthyFs) Remove(name string) error {
+	fs, err := h.current()
+	if err != nil {
+		return err
+	}
+	return fs.Remove(name)
+}
+
+func (h *HealthyFs) RemoveAll(path string) error {
+	fs, err := h.current()
+	if err != nil {
+		return err
+	}
+	return fs.RemoveAll(path)
+}
+
+func (h *HealthyFs) Rename(oldname, newname string) error {
+	fs, err := h.current()
+	if err != nil {
+		return err
+	}
+	return fs.Rename(oldname, newname)
+}
+
+func (h *HealthyFs) Name() string {
+	return "HealthyFs(" + h.remote + ")"
+}
+
+func (h *HealthyFs) Chmod(name string, mode os.FileMode) error {
+	fs, err := h.current()
+	if err != nil {
+		return err
+	}
+	return fs.Chmod(name, mode)
+}
+
+func (h *HealthyFs) Chown(name string, uid, gid int) error {
+	fs, err := h.current()
+	if err != nil {
+		return err
+	}
+	return fs.Chown(name, uid, gid)
+}
+
+func (h *HealthyFs) Chtimes(name string, atime, mtime time.Time) error {
+	fs, err := h.current()
+	if err != nil {
+		return err
+	}
+	return fs.Chtimes(name, atime, mtime)
+}
@@ -0,0 +1,131 @@
+package health
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// newTestHealthyFs 跳过 New()，避免启动后台 loop() goroutine 干扰测试对
+// probe()/reconnect() 的直接断言
+func newTestHealthyFs(fs afero.Fs, rebuild Rebuilder) *HealthyFs {
+	return &HealthyFs{
+		remote:   "test",
+		interval: time.Hour,
+		rebuild:  rebuild,
+		fs:       fs,
+		stopCh:   make(chan struct{}),
+		status:   Status{State: StateHealthy, LastProbe: time.Now()},
+	}
+}
+
+type statFailFs struct {
+	afero.Fs
+	err error
+}
+
+func (f *statFailFs) Stat(name string) (os.FileInfo, error) {
+	return nil, f.err
+}
+
+type toggleStatFs struct {
+	afero.Fs
+	fail bool
+}
+
+func (f *toggleStatFs) Stat(name string) (os.FileInfo, error) {
+	if f.fail {
+		return nil, errors.New("boom")
+	}
+	return nil, nil
+}
+
+func TestHealthyFsCircuitOpensAfterThreshold(t *testing.T) {
+	h := newTestHealthyFs(&statFailFs{err: errors.New("boom")}, func() (afero.Fs, error) {
+		return nil, errors.New("rebuild not expected in this test")
+	})
+
+	for i := 0; i < failureThreshold; i++ {
+		if h.probe() {
+			t.Fatalf("probe #%d unexpectedly succeeded", i)
+		}
+	}
+
+	if h.Status().State != StateDown {
+		t.Fatalf("expected StateDown after %d failures, got %v", failureThreshold, h.Status().State)
+	}
+
+	if _, err := h.current(); !errors.Is(err, ErrRemoteUnavailable) {
+		t.Fatalf("expected ErrRemoteUnavailable once circuit is open, got %v", err)
+	}
+}
+
+func TestHealthyFsReconnectClosesCircuit(t *testing.T) {
+	good := afero.NewMemMapFs()
+	h := newTestHealthyFs(&statFailFs{err: errors.New("boom")}, func() (afero.Fs, error) {
+		return good, nil
+	})
+
+	for i := 0; i < failureThreshold; i++ {
+		h.probe()
+	}
+	if h.Status().State != StateDown {
+		t.Fatalf("expected StateDown, got %v", h.Status().State)
+	}
+
+	if err := h.reconnect(); err != nil {
+		t.Fatalf("reconnect returned error: %v", err)
+	}
+
+	if h.Status().State != StateHealthy {
+		t.Fatalf("expected StateHealthy after reconnect, got %v", h.Status().State)
+	}
+	if _, err := h.current(); err != nil {
+		t.Fatalf("current() returned error after reconnect: %v", err)
+	}
+}
+
+func TestHealthyFsInterruptibleSleepStopsOnClose(t *testing.T) {
+	h := newTestHealthyFs(afero.NewMemMapFs(), nil)
+
+	done := make(chan bool, 1)
+	go func() { done <- h.interruptibleSleep(time.Hour) }()
+
+	time.Sleep(10 * time.Millisecond)
+	h.Close()
+
+	select {
+	case woke := <-done:
+		if woke {
+			t.Fatal("interruptibleSleep reported a normal timeout despite Close")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("interruptibleSleep did not return promptly after Close")
+	}
+}
+
+func TestHealthyFsProbeRecoverWithoutReconnect(t *testing.T) {
+	fs := &toggleStatFs{}
+	h := newTestHealthyFs(fs, func() (afero.Fs, error) {
+		return nil, errors.New("rebuild not expected in this test")
+	})
+
+	fs.fail = true
+	if h.probe() {
+		t.Fatal("expected probe to fail")
+	}
+	if h.Status().State != StateDegraded {
+		t.Fatalf("expected StateDegraded after a single failure, got %v", h.Status().State)
+	}
+
+	fs.fail = false
+	if !h.probe() {
+		t.Fatal("expected probe to succeed")
+	}
+	if h.Status().State != StateHealthy {
+		t.Fatalf("expected StateHealthy after recovery, got %v", h.Status().State)
+	}
+}
@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// ManagedBindFs 实现 afero.Fs，每次调用都从 BindManager 中查找最新的绑定表，
+// 取代 rclonefs.NewBindPathFs 在启动时固定 bindPoints 的做法，使得运行时增删
+// 绑定可以立即生效。
+type ManagedBindFs struct {
+	manager *BindManager
+	root    afero.Fs
+}
+
+// NewManagedBindFs 构建一个由 manager 支撑的绑定文件系统，root 作为未命中任何
+// 绑定前缀时的兜底文件系统（通常是 afero.NewOsFs()）
+func NewManagedBindFs(manager *BindManager, root afero.Fs) *ManagedBindFs {
+	return &ManagedBindFs{manager: manager, root: root}
+}
+
+// resolve 根据 path 前缀匹配当前生效的绑定，返回命中的 fs 与去除前缀后的相对路径
+func (fs *ManagedBindFs) resolve(path string) (afero.Fs, string) {
+	best := ""
+	var bestFs afero.Fs
+	for _, bind := range fs.manager.List() {
+		if bind.Target == "/" {
+			continue
+		}
+		if path == bind.Target || strings.HasPrefix(path, bind.Target+string(os.PathSeparator)) {
+			if len(bind.Target) > len(best) {
+				if f, ok := fs.manager.Resolve(bind.Target); ok {
+					best = bind.Target
+					bestFs = f
+				}
+			}
+		}
+	}
+
+	if bestFs == nil {
+		return fs.root, path
+	}
+
+	rel := strings.TrimPrefix(path, best)
+	if rel == "" {
+		rel = "/"
+	}
+	return bestFs, rel
+}
+
+func (fs *ManagedBindFs) Open(name string) (afero.File, error) {
+	target, rel := fs.resolve(name)
+	return target.Open(rel)
+}
+
+func (fs *ManagedBindFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	target, rel := fs.resolve(name)
+	return target.OpenFile(rel, flag, perm)
+}
+
+func (fs *ManagedBindFs) Stat(name string) (os.FileInfo, error) {
+	target, rel := fs.resolve(name)
+	return target.Stat(rel)
+}
+
+func (fs *ManagedBindFs) Create(name string) (afero.File, error) {
+	target, rel := fs.resolve(name)
+	return target.Create(rel)
+}
+
+func (fs *ManagedBindFs) Mkdir(name string, perm os.FileMode) error {
+	target, rel := fs.resolve(name)
+	return target.Mkdir(rel, perm)
+}
+
+func (fs *ManagedBindFs) MkdirAll(path string, perm os.FileMode) error {
+	target, rel := fs.resolve(path)
+	return target.MkdirAll(rel, perm)
+}
+
+func (fs *ManagedBindFs) Remove(name string) error {
+	target, rel := fs.resolve(name)
+	return target.Remove(rel)
+}
+
+func (fs *ManagedBindFs) RemoveAll(path string) error {
+	target, rel := fs.resolve(path)
+	return target.RemoveAll(rel)
+}
+
+func (fs *ManagedBindFs) Rename(oldname, newname string) error {
+	target, rel := fs.resolve(oldname)
+	_, newRel := fs.resolve(newname)
+	return target.Rename(rel, newRel)
+}
+
+func (fs *ManagedBindFs) Name() string {
+	return "ManagedBindFs"
+}
+
+func (fs *ManagedBindFs) Chmod(name string, mode os.FileMode) error {
+	target, rel := fs.resolve(name)
+	return target.Chmod(rel, mode)
+}
+
+func (fs *ManagedBindFs) Chown(name string, uid, gid int) error {
+	target, rel := fs.resolve(name)
+	return target.Chown(rel, uid, gid)
+}
+
+func (fs *ManagedBindFs) Chtimes(name string, atime, mtime time.Time) error {
+	target, rel := fs.resolve(name)
+	return target.Chtimes(rel, atime, mtime)
+}
@@ -0,0 +1,212 @@
+package manager
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/backend"
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/bind"
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/health"
+	"github.com/cloudreve/Cloudreve/v3/pkg/util"
+	"github.com/spf13/afero"
+)
+
+// Bind 描述一个挂载点与其对应的 RClone Remote
+type Bind struct {
+	Target string
+	Remote string
+	Opts   bind.Options
+	// Health 为 nil 表示该绑定的 HealthInterval<=0，未启用健康检查/熔断
+	Health *health.Status
+}
+
+// entry 是 BindManager 内部记录的绑定，多存了 Remote/Opts 以便 List/持久化
+// 时能还原出完整的 "target:remote:opts" 描述
+type entry struct {
+	fs     afero.Fs
+	remote string
+	opts   bind.Options
+	// healthy 非空时，fs 就是它本身（*health.HealthyFs 实现了 afero.Fs），
+	// 单独保留引用是为了在 Remove/Reload 时调用 Close 停止探测 goroutine
+	healthy *health.HealthyFs
+}
+
+// BindManager 运行时 RClone 绑定管理器，取代 initRCloneBind 中一次性构建的
+// bindPoints map。所有挂载的增删改查都经过这里，并委托给 backend.MountBackend
+// 完成真正的跨平台挂载/卸载，使得 BindPathFs 不再需要在启动时固定下来。
+type BindManager struct {
+	mu      sync.RWMutex
+	backend backend.MountBackend
+	binds   map[string]entry
+	order   []string // 记录绑定顺序，List/持久化时保持与配置文件一致
+}
+
+// NewBindManager 构建一个空的 BindManager，所有挂载都通过 backend 完成
+func NewBindManager(backend backend.MountBackend) *BindManager {
+	return &BindManager{
+		backend: backend,
+		binds:   make(map[string]entry),
+	}
+}
+
+// Resolve 返回 target 路径下挂载的 afero.Fs，未命中时返回 ok=false
+func (m *BindManager) Resolve(target string) (afero.Fs, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	e, ok := m.binds[target]
+	if !ok {
+		return nil, false
+	}
+	return e.fs, true
+}
+
+// List 返回当前所有绑定，按配置顺序排列
+func (m *BindManager) List() []Bind {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	binds := make([]Bind, 0, len(m.order))
+	for _, target := range m.order {
+		if e, ok := m.binds[target]; ok {
+			b := Bind{Target: target, Remote: e.remote, Opts: e.opts}
+			if e.healthy != nil {
+				s := e.healthy.Status()
+				b.Health = &s
+			}
+			binds = append(binds, b)
+		}
+	}
+	return binds
+}
+
+// Add 通过 backend 挂载一个新的 target:remote，已存在时返回错误。当
+// opts.HealthInterval 大于 0 时，挂载结果会被包一层 health.HealthyFs 做周期
+// 探测和熔断
+func (m *BindManager) Add(target, remote string, opts bind.Options) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.binds[target]; ok {
+		return fmt.Errorf("绑定 '%s' 已存在", target)
+	}
+
+	fs, err := m.backend.Mount(target, remote, opts)
+	if err != nil {
+		return fmt.Errorf("绑定 '%s' 挂载失败: %w", target, err)
+	}
+
+	e := entry{fs: fs, remote: remote, opts: opts}
+	if opts.HealthInterval > 0 {
+		rebuild := func() (afero.Fs, error) {
+			if err := m.backend.Unmount(target); err != nil {
+				util.Log().Warning("重连绑定 '%s' 前卸载旧连接出错: %s", target, err)
+			}
+			return m.backend.Mount(target, remote, opts)
+		}
+		e.healthy = health.New(remote, opts.HealthInterval, fs, rebuild)
+		e.fs = e.healthy
+	}
+
+	m.binds[target] = e
+	m.order = append(m.order, target)
+	util.Log().Info("RClone绑定已添加: '%s' -> '%s'", target, remote)
+	return nil
+}
+
+// Remove 通过 backend 卸载 target 处的绑定，不存在时返回错误
+func (m *BindManager) Remove(target string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.binds[target]
+	if !ok {
+		return fmt.Errorf("绑定 '%s' 不存在", target)
+	}
+
+	if e.healthy != nil {
+		e.healthy.Close()
+	}
+
+	if err := m.backend.Unmount(target); err != nil {
+		util.Log().Warning("卸载绑定 '%s' 时出错: %s", target, err)
+	}
+
+	delete(m.binds, target)
+	for i, t := range m.order {
+		if t == target {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+	util.Log().Info("RClone绑定已移除: '%s'", target)
+	return nil
+}
+
+// Reload 将 specs（解析自配置文件的 target/remote/opts 三元组）与当前状态比较，
+// 新增的绑定被挂载，消失的绑定被卸载，选项变化的绑定被重建
+func (m *BindManager) Reload(specs []Bind) {
+	m.mu.Lock()
+	wanted := make(map[string]Bind, len(specs))
+	for _, s := range specs {
+		wanted[s.Target] = s
+	}
+
+	// 卸载已移除或选项变化的绑定
+	for target, e := range m.binds {
+		s, ok := wanted[target]
+		if ok && s.Remote == e.remote && s.Opts == e.opts {
+			continue
+		}
+		if e.healthy != nil {
+			e.healthy.Close()
+		}
+		if err := m.backend.Unmount(target); err != nil {
+			util.Log().Warning("重新加载时卸载 '%s' 出错: %s", target, err)
+		}
+		delete(m.binds, target)
+	}
+	m.mu.Unlock()
+
+	// 挂载新增或需要重建的绑定；这段期间 mu 是反复加解锁的（m.Add 自己也要加
+	// 锁），所以 Add/Remove 这类管理API调用可能跟这里的挂载动作交错执行
+	for _, s := range specs {
+		m.mu.RLock()
+		_, exists := m.binds[s.Target]
+		m.mu.RUnlock()
+		if exists {
+			continue
+		}
+		if err := m.Add(s.Target, s.Remote, s.Opts); err != nil {
+			util.Log().Warning("重新加载时挂载 '%s' 出错: %s", s.Target, err)
+		}
+	}
+
+	// 重建 order 时不能只看 specs：上面这段期间如果有并发的 Add/Remove 调用
+	// （例如管理API同时发起的请求），m.binds 相对 specs 已经发生了变化，直接
+	// 用 specs 算出来的顺序覆盖 m.order 会让这些并发变更在 List()/持久化时
+	// 凭空消失。改成在最终持锁的状态下，按 specs 顺序过滤出仍然存在的绑定，
+	// 再把不在 specs 里、但确实还挂载着的绑定（即并发新增的）接到后面
+	m.mu.Lock()
+	order := make([]string, 0, len(m.binds))
+	seen := make(map[string]bool, len(m.binds))
+	for _, s := range specs {
+		if _, ok := m.binds[s.Target]; ok && !seen[s.Target] {
+			order = append(order, s.Target)
+			seen[s.Target] = true
+		}
+	}
+	for _, target := range m.order {
+		if seen[target] {
+			continue
+		}
+		if _, ok := m.binds[target]; ok {
+			order = append(order, target)
+			seen[target] = true
+		}
+	}
+	m.order = order
+	m.mu.Unlock()
+
+	util.Log().Info("RClone绑定已重新加载，共 %d 个绑定", len(specs))
+}
@@ -0,0 +1,72 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/bind"
+	"github.com/spf13/afero"
+)
+
+// fakeBackend 是测试用的 backend.MountBackend 实现，不做任何真正的挂载，只
+// 记录调用次数方便断言
+type fakeBackend struct {
+	mounts   map[string]int
+	unmounts map[string]int
+	// onMount 非空时在每次 Mount 中被调用，调用方 m.Add 此时持有 m.mu
+	onMount func(target string)
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{mounts: map[string]int{}, unmounts: map[string]int{}}
+}
+
+func (b *fakeBackend) Name() string { return "fake" }
+
+func (b *fakeBackend) Mount(target, remote string, opts bind.Options) (afero.Fs, error) {
+	b.mounts[target]++
+	if b.onMount != nil {
+		b.onMount(target)
+	}
+	return afero.NewMemMapFs(), nil
+}
+
+func (b *fakeBackend) Unmount(target string) error {
+	b.unmounts[target]++
+	return nil
+}
+
+func TestBindManagerAddListRemove(t *testing.T) {
+	backend := newFakeBackend()
+	m := NewBindManager(backend)
+
+	if err := m.Add("/mnt/a", "remoteA", bind.Options{}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if err := m.Add("/mnt/a", "remoteA", bind.Options{}); err == nil {
+		t.Fatal("expected error adding a duplicate target")
+	}
+
+	binds := m.List()
+	if len(binds) != 1 || binds[0].Target != "/mnt/a" || binds[0].Remote != "remoteA" {
+		t.Fatalf("unexpected List() result: %+v", binds)
+	}
+
+	if _, ok := m.Resolve("/mnt/a"); !ok {
+		t.Fatal("expected Resolve to find /mnt/a")
+	}
+
+	if err := m.Remove("/mnt/a"); err != nil {
+		t.Fatalf("Remove returned error: %v", err)
+	}
+	if err := m.Remove("/mnt/a"); err == nil {
+		t.Fatal("expected error removing a bind that no longer exists")
+	}
+
+	if backend.mounts["/mnt/a"] != 1 || backend.unmounts["/mnt/a"] != 1 {
+		t.Fatalf("unexpected backend call counts: mounts=%+v unmounts=%+v", backend.mounts, backend.unmounts)
+	}
+
+	if len(m.List()) != 0 {
+		t.Fatalf("expected no binds after Remove, got %+v", m.List())
+	}
+}
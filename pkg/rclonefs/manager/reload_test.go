@@ -0,0 +1,95 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/cloudreve/Cloudreve/v3/pkg/rclonefs/bind"
+)
+
+func TestBindManagerReloadKeepsOrderAndAppliesDiff(t *testing.T) {
+	backend := newFakeBackend()
+	m := NewBindManager(backend)
+
+	if err := m.Add("/mnt/a", "remoteA", bind.Options{}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if err := m.Add("/mnt/b", "remoteB", bind.Options{}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	// /mnt/a 保持不变，/mnt/b 被移除，/mnt/c 是新增的
+	m.Reload([]Bind{
+		{Target: "/mnt/a", Remote: "remoteA", Opts: bind.Options{}},
+		{Target: "/mnt/c", Remote: "remoteC", Opts: bind.Options{}},
+	})
+
+	binds := m.List()
+	if len(binds) != 2 {
+		t.Fatalf("expected 2 binds after Reload, got %+v", binds)
+	}
+	if binds[0].Target != "/mnt/a" || binds[1].Target != "/mnt/c" {
+		t.Fatalf("unexpected order after Reload: %+v", binds)
+	}
+
+	if backend.unmounts["/mnt/b"] != 1 {
+		t.Fatalf("expected /mnt/b to be unmounted, got counts: %+v", backend.unmounts)
+	}
+	if backend.mounts["/mnt/a"] != 1 {
+		t.Fatalf("unchanged bind /mnt/a should not be remounted, got counts: %+v", backend.mounts)
+	}
+	if backend.mounts["/mnt/c"] != 1 {
+		t.Fatalf("expected /mnt/c to be mounted, got counts: %+v", backend.mounts)
+	}
+}
+
+func TestBindManagerReloadRebuildsChangedOptions(t *testing.T) {
+	backend := newFakeBackend()
+	m := NewBindManager(backend)
+
+	if err := m.Add("/mnt/a", "remoteA", bind.Options{}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	m.Reload([]Bind{{Target: "/mnt/a", Remote: "remoteA", Opts: bind.Options{ReadOnly: true}}})
+
+	if backend.unmounts["/mnt/a"] != 1 || backend.mounts["/mnt/a"] != 2 {
+		t.Fatalf("expected /mnt/a to be unmounted and remounted once, got mounts=%d unmounts=%d",
+			backend.mounts["/mnt/a"], backend.unmounts["/mnt/a"])
+	}
+}
+
+// TestBindManagerReloadPreservesConcurrentAdd 模拟 Reload 运行期间有一次不在
+// specs 里的并发 Add（比如管理API同时收到一个请求）：那个绑定不应该从 order
+// 里消失，否则 List()/持久化会看不到一个实际上仍然挂载着的绑定
+func TestBindManagerReloadPreservesConcurrentAdd(t *testing.T) {
+	backend := newFakeBackend()
+	m := NewBindManager(backend)
+
+	if err := m.Add("/mnt/a", "remoteA", bind.Options{}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	// Reload 挂载新增的 /mnt/b 时直接往 m.binds/m.order 追加一个绑定，模拟
+	// Reload 卸载/挂载阶段之间发生的一次并发 Add。Mount 由持有 m.mu 的 m.Add
+	// 调用，所以这里不再加锁
+	backend.onMount = func(target string) {
+		if target != "/mnt/b" {
+			return
+		}
+		m.binds["/mnt/concurrent"] = entry{fs: nil, remote: "remoteX", opts: bind.Options{}}
+		m.order = append(m.order, "/mnt/concurrent")
+	}
+
+	m.Reload([]Bind{
+		{Target: "/mnt/a", Remote: "remoteA", Opts: bind.Options{}},
+		{Target: "/mnt/b", Remote: "remoteB", Opts: bind.Options{}},
+	})
+
+	binds := m.List()
+	if len(binds) != 3 {
+		t.Fatalf("expected concurrently added bind to survive Reload, got %+v", binds)
+	}
+	if binds[0].Target != "/mnt/a" || binds[1].Target != "/mnt/b" || binds[2].Target != "/mnt/concurrent" {
+		t.Fatalf("unexpected order after Reload: %+v", binds)
+	}
+}
@@ -0,0 +1,12 @@
+package routers
+
+import (
+	"github.com/cloudreve/Cloudreve/v3/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+// InitConfAdminRouter 把配置文件相关的后台API注册到 group 上，调用方传入的
+// group 应为既有的 /api/v3/admin 鉴权分组
+func InitConfAdminRouter(group *gin.RouterGroup) {
+	group.POST("conf/reload", controllers.ReloadConfig)
+}
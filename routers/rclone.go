@@ -0,0 +1,19 @@
+package routers
+
+import (
+	"github.com/cloudreve/Cloudreve/v3/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+// InitRCloneAdminRouter 把 RClone 绑定管理相关的后台API注册到 group 下的
+// rclone 子分组，调用方传入的 group 应为既有的 /api/v3/admin 鉴权分组
+func InitRCloneAdminRouter(group *gin.RouterGroup) {
+	rclone := group.Group("rclone")
+	{
+		rclone.GET("binds", controllers.ListRCloneBinds)
+		rclone.POST("binds", controllers.AddRCloneBind)
+		rclone.DELETE("binds", controllers.RemoveRCloneBind)
+		rclone.POST("binds/reload", controllers.ReloadRCloneBinds)
+		rclone.GET("status", controllers.RCloneStatus)
+	}
+}
@@ -0,0 +1,13 @@
+package routers
+
+import "github.com/gin-gonic/gin"
+
+// InitAdminRouter 把这个系列新增的后台管理API（RClone绑定管理、配置热重载）
+// 挂载到既有的 admin 鉴权分组下。本仓库顶层构建 /api/v3/admin 分组、鉴权中间件
+// 及其余 admin 路由的 InitRouter 不在这次改动范围内，需要在那里对已经建好的
+// admin *gin.RouterGroup 补一行 routers.InitAdminRouter(admin)，这两组新接口
+// 才会在真实服务里生效
+func InitAdminRouter(admin *gin.RouterGroup) {
+	InitRCloneAdminRouter(admin)
+	InitConfAdminRouter(admin)
+}
@@ -0,0 +1,41 @@
+package routers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestInitAdminRouterMountsRoutes 从 gin.Engine 发起真实HTTP请求走完整路由
+// 分发，确认 InitAdminRouter 挂到 admin 分组下之后这几条路径真的能被命中，
+// 而不是只检查 Init*Router 函数本身不报错
+func TestInitAdminRouterMountsRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	admin := r.Group("api/v3/admin")
+	InitAdminRouter(admin)
+
+	cases := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/v3/admin/rclone/binds"},
+		{http.MethodPost, "/api/v3/admin/rclone/binds"},
+		{http.MethodDelete, "/api/v3/admin/rclone/binds"},
+		{http.MethodPost, "/api/v3/admin/rclone/binds/reload"},
+		{http.MethodGet, "/api/v3/admin/rclone/status"},
+		{http.MethodPost, "/api/v3/admin/conf/reload"},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, c.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code == http.StatusNotFound {
+			t.Fatalf("%s %s: route not mounted, got 404", c.method, c.path)
+		}
+	}
+}